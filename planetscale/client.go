@@ -0,0 +1,277 @@
+package planetscale
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL = "https://api.planetscale.com/"
+
+	mediaType = "application/json"
+
+	userAgent = "planetscale-go"
+)
+
+// Client is a client for the PlanetScale API.
+type Client struct {
+	client *http.Client
+
+	BaseURL *url.URL
+
+	UserAgent string
+
+	AccessToken string
+
+	DeployRequests DeployRequestsService
+	Passwords      PasswordsService
+}
+
+// ClientOption is an option that can be passed to NewClient to customize the
+// client that is returned.
+type ClientOption func(*Client) error
+
+// NewClient creates a new PlanetScale API client.
+func NewClient(opts ...ClientOption) (*Client, error) {
+	baseURL, err := url.Parse(defaultBaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		client:    http.DefaultClient,
+		BaseURL:   baseURL,
+		UserAgent: userAgent,
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	c.DeployRequests = NewDeployRequestsService(c)
+	c.Passwords = NewPasswordsService(c)
+
+	return c, nil
+}
+
+// WithBaseURL overrides the base URL used for API requests. This is
+// primarily useful for testing against a local httptest.Server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithAccessToken configures a client to authenticate requests with the
+// given PlanetScale access token.
+func WithAccessToken(token string) ClientOption {
+	return func(c *Client) error {
+		c.AccessToken = token
+		return nil
+	}
+}
+
+// WithHTTPClient configures a client to use a custom *http.Client for
+// performing requests.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) error {
+		c.client = httpClient
+		return nil
+	}
+}
+
+func (c *Client) newRequest(method, urlPath string, body interface{}) (*http.Request, error) {
+	rel, err := url.Parse(urlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.BaseURL.ResolveReference(rel)
+
+	var buf io.ReadWriter
+	if body != nil {
+		buf = new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", mediaType)
+	}
+	req.Header.Set("Accept", mediaType)
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	if c.AccessToken != "" {
+		req.Header.Set("Authorization", c.AccessToken)
+	}
+
+	return req, nil
+}
+
+// Do sends an API request and returns the API response, decorated with
+// rate-limit and pagination metadata parsed from the HTTP response.
+func (c *Client) Do(ctx context.Context, req *http.Request) (*Response, error) {
+	req = req.WithContext(ctx)
+
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := newResponse(httpResp)
+
+	if err := CheckResponse(httpResp); err != nil {
+		defer httpResp.Body.Close()
+		if httpResp.StatusCode == http.StatusTooManyRequests {
+			return resp, &RateLimitError{
+				Rate:     resp.Rate,
+				Response: httpResp,
+			}
+		}
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Response wraps the standard http.Response and surfaces rate-limit and
+// pagination metadata parsed from the response headers.
+type Response struct {
+	*http.Response
+
+	Rate
+	Links
+}
+
+func newResponse(r *http.Response) *Response {
+	resp := &Response{Response: r}
+	resp.Rate = parseRate(r)
+	resp.Links = parseLinks(r)
+	return resp
+}
+
+// Rate contains the rate-limit information returned in the response headers
+// of an API call.
+type Rate struct {
+	// Limit is the maximum number of requests allowed in the current window.
+	Limit int
+
+	// Remaining is the number of requests remaining in the current window.
+	Remaining int
+
+	// Reset is the time at which the current rate-limit window resets.
+	Reset time.Time
+}
+
+func parseRate(r *http.Response) Rate {
+	var rate Rate
+
+	if limit := r.Header.Get("X-RateLimit-Limit"); limit != "" {
+		rate.Limit, _ = strconv.Atoi(limit)
+	}
+
+	if remaining := r.Header.Get("X-RateLimit-Remaining"); remaining != "" {
+		rate.Remaining, _ = strconv.Atoi(remaining)
+	}
+
+	if reset := r.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if v, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			rate.Reset = time.Unix(v, 0)
+		}
+	}
+
+	return rate
+}
+
+// Links contains pagination cursors parsed from a list response.
+type Links struct {
+	Next string
+	Prev string
+}
+
+// parseLinks parses the pagination cursors out of the response's "Link"
+// header (RFC 5988), e.g.:
+//
+//	Link: <https://api.planetscale.com/v1/.../passwords?page=2>; rel="next"
+func parseLinks(r *http.Response) Links {
+	var links Links
+
+	for _, part := range strings.Split(r.Header.Get("Link"), ",") {
+		sections := strings.Split(strings.TrimSpace(part), ";")
+		if len(sections) < 2 {
+			continue
+		}
+
+		url := strings.TrimSpace(sections[0])
+		url = strings.TrimPrefix(url, "<")
+		url = strings.TrimSuffix(url, ">")
+
+		for _, param := range sections[1:] {
+			param = strings.TrimSpace(param)
+			rel := strings.TrimPrefix(param, `rel="`)
+			rel = strings.TrimSuffix(rel, `"`)
+
+			switch rel {
+			case "next":
+				links.Next = url
+			case "prev":
+				links.Prev = url
+			}
+		}
+	}
+
+	return links
+}
+
+// ListOptions specifies pagination options for API calls that return a list
+// of resources.
+type ListOptions struct {
+	// Page is the page number to fetch, starting at 1.
+	Page int `url:"page,omitempty"`
+
+	// PerPage is the number of items to return per page.
+	PerPage int `url:"per_page,omitempty"`
+}
+
+func addListOptions(urlPath string, opts ListOptions) (string, error) {
+	if opts.Page == 0 && opts.PerPage == 0 {
+		return urlPath, nil
+	}
+
+	u, err := url.Parse(urlPath)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	if opts.Page != 0 {
+		q.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage != 0 {
+		q.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
@@ -0,0 +1,114 @@
+package planetscale
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseRate(t *testing.T) {
+	c := qt.New(t)
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "600")
+	header.Set("X-RateLimit-Remaining", "599")
+	header.Set("X-RateLimit-Reset", "1610619563")
+
+	rate := parseRate(&http.Response{Header: header})
+
+	c.Assert(rate, qt.DeepEquals, Rate{
+		Limit:     600,
+		Remaining: 599,
+		Reset:     time.Unix(1610619563, 0),
+	})
+}
+
+func TestParseRate_missingHeaders(t *testing.T) {
+	c := qt.New(t)
+
+	rate := parseRate(&http.Response{Header: http.Header{}})
+
+	c.Assert(rate, qt.DeepEquals, Rate{})
+}
+
+func TestClient_Do_rateLimitError(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "600")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1610619563")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, err := w.Write([]byte(`{"code": "rate_limited", "message": "too many requests"}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, _, err = client.Passwords.List(context.Background(), &ListDatabaseBranchPasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+	})
+
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	var rateLimitErr *RateLimitError
+	c.Assert(errors.As(err, &rateLimitErr), qt.IsTrue)
+	c.Assert(rateLimitErr.Rate.Remaining, qt.Equals, 0)
+}
+
+func TestResponse_Links(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://api.planetscale.com/v1/organizations/my-org/databases/my-db/branches/my-branch/passwords?page=2>; rel="next", <https://api.planetscale.com/v1/organizations/my-org/databases/my-db/branches/my-branch/passwords?page=1>; rel="prev"`)
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`{"data":[]}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, res, err := client.Passwords.List(context.Background(), &ListDatabaseBranchPasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Links.Next, qt.Equals, "https://api.planetscale.com/v1/organizations/my-org/databases/my-db/branches/my-branch/passwords?page=2")
+	c.Assert(res.Links.Prev, qt.Equals, "https://api.planetscale.com/v1/organizations/my-org/databases/my-db/branches/my-branch/passwords?page=1")
+}
+
+func TestResponse_Links_absent(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`{"data":[]}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, res, err := client.Passwords.List(context.Background(), &ListDatabaseBranchPasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Links, qt.DeepEquals, Links{})
+}
@@ -0,0 +1,13 @@
+package planetscale
+
+import "fmt"
+
+// databasesAPIPath gets the base path for accessing a single organization's
+// databases.
+func databasesAPIPath(org string) string {
+	return fmt.Sprintf("%s/%s/databases", organizationsAPIPath(), org)
+}
+
+func organizationsAPIPath() string {
+	return "v1/organizations"
+}
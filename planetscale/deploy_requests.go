@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -24,6 +26,65 @@ type PerformDeployRequest struct {
 	Organization string `json:"-"`
 	Database     string `json:"-"`
 	Number       uint64 `json:"-"`
+
+	// SkipRevert deploys even if a previous deploy of this request failed
+	// and was reverted.
+	SkipRevert bool `json:"skip_revert,omitempty"`
+
+	// AutoApply deploys automatically once the deploy request becomes
+	// deployable, instead of requiring a separate call to Deploy.
+	AutoApply bool `json:"auto_apply,omitempty"`
+}
+
+// DiffDeployRequest encapsulates the request for fetching the schema diff of
+// a deploy request prior to deploying it.
+type DiffDeployRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Number       uint64 `json:"-"`
+}
+
+// SchemaOperation is a single schema change proposed by a deploy request.
+type SchemaOperation struct {
+	Table  string `json:"table"`
+	Kind   string `json:"kind"` // create, alter, drop, rename
+	RawSQL string `json:"raw_sql"`
+
+	EstimatedRows   uint64 `json:"estimated_rows"`
+	RequiresRebuild bool   `json:"requires_rebuild"`
+}
+
+// DiffWarning flags a potential issue with a proposed schema change that
+// isn't severe enough to block the deploy outright.
+type DiffWarning struct {
+	Table   string `json:"table"`
+	Message string `json:"message"`
+}
+
+// DeployDiff is the structured schema diff for a deploy request, fetched
+// before approving and deploying it.
+type DeployDiff struct {
+	Operations []*SchemaOperation `json:"operations"`
+	Warnings   []*DiffWarning     `json:"warnings"`
+	Raw        string             `json:"raw"`
+}
+
+// ContainsDestructive reports whether the diff contains an operation that
+// could lose data or break existing queries: a drop, a NOT NULL column
+// addition with no default, or a primary key change.
+func (d *DeployDiff) ContainsDestructive() bool {
+	for _, op := range d.Operations {
+		switch {
+		case op.Kind == "drop":
+			return true
+		case op.Kind == "alter" && isNonNullColumnAdditionWithoutDefault(op.RawSQL):
+			return true
+		case op.Kind == "alter" && isPrimaryKeyChange(op.RawSQL):
+			return true
+		}
+	}
+
+	return false
 }
 
 // GetDeployRequest encapsulates the request for getting a single deploy
@@ -39,6 +100,8 @@ type GetDeployRequestRequest struct {
 type ListDeployRequestsRequest struct {
 	Organization string
 	Database     string
+
+	ListOptions
 }
 
 // DeployRequest encapsulates the request to deploy a database branch's schema
@@ -62,11 +125,23 @@ type DeployRequest struct {
 
 	Notes string `json:"notes"`
 
+	Operations []*DeployOperation `json:"operations"`
+
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	ClosedAt  *time.Time `json:"closed_at"`
 }
 
+// DeployOperation describes the progress of a single table copy as part of a
+// deploy request's underlying schema change.
+type DeployOperation struct {
+	State      string  `json:"state"`
+	Table      string  `json:"table"`
+	ETASeconds float64 `json:"eta_seconds"`
+	RowsCopied uint64  `json:"rows_copied"`
+	RowsTotal  uint64  `json:"rows_total"`
+}
+
 type CancelDeployRequest struct {
 	Organization string `json:"-"`
 	Database     string `json:"-"`
@@ -92,13 +167,48 @@ type ReviewDeployRequestRequest struct {
 // DeployRequestsService is an interface for communicating with the PlanetScale
 // deploy requests API.
 type DeployRequestsService interface {
-	List(context.Context, *ListDeployRequestsRequest) ([]*DeployRequest, error)
-	Create(context.Context, *CreateDeployRequestRequest) (*DeployRequest, error)
-	Get(context.Context, *GetDeployRequestRequest) (*DeployRequest, error)
-	Deploy(context.Context, *PerformDeployRequest) (*DeployRequest, error)
-	CancelDeploy(context.Context, *CancelDeployRequest) (*DeployRequest, error)
-	Close(context.Context, *CloseDeployRequestRequest) (*DeployRequest, error)
-	CreateReview(context.Context, *ReviewDeployRequestRequest) (*DeployRequestReview, error)
+	List(context.Context, *ListDeployRequestsRequest) ([]*DeployRequest, *Response, error)
+	Create(context.Context, *CreateDeployRequestRequest) (*DeployRequest, *Response, error)
+	Get(context.Context, *GetDeployRequestRequest) (*DeployRequest, *Response, error)
+	Deploy(context.Context, *PerformDeployRequest) (*DeployRequest, *Response, error)
+	CancelDeploy(context.Context, *CancelDeployRequest) (*DeployRequest, *Response, error)
+	Close(context.Context, *CloseDeployRequestRequest) (*DeployRequest, *Response, error)
+	CreateReview(context.Context, *ReviewDeployRequestRequest) (*DeployRequestReview, *Response, error)
+
+	// Diff fetches the structured schema diff a deploy request would apply,
+	// so callers can gate on it before calling Deploy.
+	Diff(context.Context, *DiffDeployRequest) (*DeployDiff, *Response, error)
+
+	// Wait polls a deploy request until its DeploymentState reaches a
+	// terminal value or ctx is cancelled.
+	Wait(context.Context, *WaitDeployRequest) (*DeployRequest, error)
+
+	// Watch behaves like Wait, but streams every polled DeployRequest as a
+	// DeployEvent so callers can render live progress.
+	Watch(context.Context, *WaitDeployRequest) <-chan DeployEvent
+}
+
+// WaitDeployRequest encapsulates the request for polling a deploy request
+// until it reaches a terminal deployment state.
+type WaitDeployRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Number       uint64 `json:"-"`
+}
+
+// DeployEvent is emitted on each poll performed by Watch.
+type DeployEvent struct {
+	DeployRequest *DeployRequest
+	LogLines      []LogLine
+	Err           error
+}
+
+// LogLine is a single line of structured progress output decoded from a
+// deploy request's operations while it is executing.
+type LogLine struct {
+	Time    time.Time
+	Level   string
+	Message string
 }
 
 type CloseDeployRequestRequest struct {
@@ -120,25 +230,25 @@ func NewDeployRequestsService(client *Client) *deployRequestsService {
 }
 
 // Get fetches a single deploy request.
-func (d *deployRequestsService) Get(ctx context.Context, getReq *GetDeployRequestRequest) (*DeployRequest, error) {
+func (d *deployRequestsService) Get(ctx context.Context, getReq *GetDeployRequestRequest) (*DeployRequest, *Response, error) {
 	req, err := d.client.newRequest(http.MethodGet, deployRequestAPIPath(getReq.Organization, getReq.Database, getReq.Number), nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	dr := &DeployRequest{}
 	err = json.NewDecoder(res.Body).Decode(dr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return dr, nil
+	return dr, res, nil
 }
 
 type CloseRequest struct {
@@ -146,112 +256,117 @@ type CloseRequest struct {
 }
 
 // Close closes a deploy request
-func (d *deployRequestsService) Close(ctx context.Context, closeReq *CloseDeployRequestRequest) (*DeployRequest, error) {
+func (d *deployRequestsService) Close(ctx context.Context, closeReq *CloseDeployRequestRequest) (*DeployRequest, *Response, error) {
 	updateReq := &CloseRequest{
 		State: "closed",
 	}
 
 	req, err := d.client.newRequest(http.MethodPatch, deployRequestAPIPath(closeReq.Organization, closeReq.Database, closeReq.Number), updateReq)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	dr := &DeployRequest{}
 	err = json.NewDecoder(res.Body).Decode(dr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return dr, nil
+	return dr, res, nil
 }
 
 // Deploy approves and executes a specific deploy request.
-func (d *deployRequestsService) Deploy(ctx context.Context, deployReq *PerformDeployRequest) (*DeployRequest, error) {
+func (d *deployRequestsService) Deploy(ctx context.Context, deployReq *PerformDeployRequest) (*DeployRequest, *Response, error) {
 	path := deployRequestActionAPIPath(deployReq.Organization, deployReq.Database, deployReq.Number, "deploy")
 	req, err := d.client.newRequest(http.MethodPost, path, deployReq)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	dr := &DeployRequest{}
 	err = json.NewDecoder(res.Body).Decode(dr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return dr, nil
+	return dr, res, nil
 }
 
 type deployRequestsResponse struct {
 	DeployRequests []*DeployRequest `json:"data"`
 }
 
-func (d *deployRequestsService) Create(ctx context.Context, createReq *CreateDeployRequestRequest) (*DeployRequest, error) {
+func (d *deployRequestsService) Create(ctx context.Context, createReq *CreateDeployRequestRequest) (*DeployRequest, *Response, error) {
 	path := deployRequestsAPIPath(createReq.Organization, createReq.Database)
 	req, err := d.client.newRequest(http.MethodPost, path, createReq)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	dr := &DeployRequest{}
 	err = json.NewDecoder(res.Body).Decode(dr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return dr, nil
+	return dr, res, nil
 }
 
 // CancelDeploy cancels a queued deploy request.
-func (d *deployRequestsService) CancelDeploy(ctx context.Context, deployReq *CancelDeployRequest) (*DeployRequest, error) {
+func (d *deployRequestsService) CancelDeploy(ctx context.Context, deployReq *CancelDeployRequest) (*DeployRequest, *Response, error) {
 	path := deployRequestActionAPIPath(deployReq.Organization, deployReq.Database, deployReq.Number, "cancel")
 	req, err := d.client.newRequest(http.MethodPost, path, deployReq)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	dr := &DeployRequest{}
 	err = json.NewDecoder(res.Body).Decode(dr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return dr, nil
+	return dr, res, nil
 }
 
-func (d *deployRequestsService) List(ctx context.Context, listReq *ListDeployRequestsRequest) ([]*DeployRequest, error) {
-	req, err := d.client.newRequest(http.MethodGet, deployRequestsAPIPath(listReq.Organization, listReq.Database), nil)
+func (d *deployRequestsService) List(ctx context.Context, listReq *ListDeployRequestsRequest) ([]*DeployRequest, *Response, error) {
+	path, err := addListOptions(deployRequestsAPIPath(listReq.Organization, listReq.Database), listReq.ListOptions)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error adding list options")
+	}
+
+	req, err := d.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
@@ -259,31 +374,173 @@ func (d *deployRequestsService) List(ctx context.Context, listReq *ListDeployReq
 	err = json.NewDecoder(res.Body).Decode(&deployRequests)
 
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 
-	return deployRequests.DeployRequests, nil
+	return deployRequests.DeployRequests, res, nil
 }
 
-func (d *deployRequestsService) CreateReview(ctx context.Context, reviewReq *ReviewDeployRequestRequest) (*DeployRequestReview, error) {
-	req, err := d.client.newRequest(http.MethodGet, deployRequestActionAPIPath(reviewReq.Organization, reviewReq.Database, reviewReq.Number, "reviews"), reviewReq)
+func (d *deployRequestsService) CreateReview(ctx context.Context, reviewReq *ReviewDeployRequestRequest) (*DeployRequestReview, *Response, error) {
+	req, err := d.client.newRequest(http.MethodPost, deployRequestActionAPIPath(reviewReq.Organization, reviewReq.Database, reviewReq.Number, "reviews"), reviewReq)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating http request")
+		return nil, nil, errors.Wrap(err, "error creating http request")
 	}
 
 	res, err := d.client.Do(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, res, err
 	}
 	defer res.Body.Close()
 
 	drr := &DeployRequestReview{}
 	err = json.NewDecoder(res.Body).Decode(drr)
 	if err != nil {
-		return nil, err
+		return nil, res, err
+	}
+
+	return drr, res, nil
+}
+
+// Diff fetches the structured schema diff a deploy request would apply.
+func (d *deployRequestsService) Diff(ctx context.Context, diffReq *DiffDeployRequest) (*DeployDiff, *Response, error) {
+	path := deployRequestActionAPIPath(diffReq.Organization, diffReq.Database, diffReq.Number, "diff")
+	req, err := d.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := d.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	diff := &DeployDiff{}
+	if err := json.NewDecoder(res.Body).Decode(diff); err != nil {
+		return nil, res, err
+	}
+
+	return diff, res, nil
+}
+
+// deployRequestWaitInitialBackoff and deployRequestWaitMaxBackoff bound the
+// exponential backoff used by Wait and Watch. They are vars rather than
+// consts so tests can shrink them instead of sleeping for real.
+var (
+	deployRequestWaitInitialBackoff = time.Second
+	deployRequestWaitMaxBackoff     = 30 * time.Second
+)
+
+// deployRequestTerminalStates are the DeploymentState values at which a
+// deploy request will no longer change and polling should stop.
+var deployRequestTerminalStates = map[string]bool{
+	"complete":  true,
+	"failed":    true,
+	"cancelled": true,
+}
+
+func isTerminalDeploymentState(state string) bool {
+	return deployRequestTerminalStates[state]
+}
+
+// isNonNullColumnAdditionWithoutDefault reports whether rawSQL adds a NOT
+// NULL column with no DEFAULT, which fails against existing rows.
+func isNonNullColumnAdditionWithoutDefault(rawSQL string) bool {
+	sql := strings.ToUpper(rawSQL)
+	return strings.Contains(sql, "ADD COLUMN") &&
+		strings.Contains(sql, "NOT NULL") &&
+		!strings.Contains(sql, "DEFAULT")
+}
+
+// isPrimaryKeyChange reports whether rawSQL alters a table's primary key.
+func isPrimaryKeyChange(rawSQL string) bool {
+	return strings.Contains(strings.ToUpper(rawSQL), "PRIMARY KEY")
+}
+
+// deployRequestWaitBackoff returns a jittered, exponentially increasing
+// delay for the given poll attempt (0-indexed), capped at
+// deployRequestWaitMaxBackoff.
+func deployRequestWaitBackoff(attempt int) time.Duration {
+	backoff := deployRequestWaitInitialBackoff * time.Duration(1<<uint(attempt))
+	if backoff <= 0 || backoff > deployRequestWaitMaxBackoff {
+		backoff = deployRequestWaitMaxBackoff
+	}
+
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff)/2+1))
+}
+
+// Wait polls a deploy request until its DeploymentState reaches a terminal
+// value or ctx is cancelled, returning the last observed DeployRequest.
+func (d *deployRequestsService) Wait(ctx context.Context, waitReq *WaitDeployRequest) (*DeployRequest, error) {
+	var last *DeployRequest
+	for ev := range d.Watch(ctx, waitReq) {
+		if ev.Err != nil {
+			return nil, ev.Err
+		}
+		last = ev.DeployRequest
+	}
+
+	return last, nil
+}
+
+// Watch behaves like Wait, but streams every polled DeployRequest as a
+// DeployEvent so callers can render live progress. The returned channel is
+// closed once a terminal state is reached, ctx is cancelled, or polling
+// fails.
+func (d *deployRequestsService) Watch(ctx context.Context, waitReq *WaitDeployRequest) <-chan DeployEvent {
+	events := make(chan DeployEvent)
+
+	go func() {
+		defer close(events)
+
+		for attempt := 0; ; attempt++ {
+			dr, _, err := d.Get(ctx, &GetDeployRequestRequest{
+				Organization: waitReq.Organization,
+				Database:     waitReq.Database,
+				Number:       waitReq.Number,
+			})
+			if err != nil {
+				select {
+				case events <- DeployEvent{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+
+			select {
+			case events <- DeployEvent{DeployRequest: dr, LogLines: deployOperationLogLines(dr.Operations)}:
+			case <-ctx.Done():
+				return
+			}
+
+			if isTerminalDeploymentState(dr.DeploymentState) {
+				return
+			}
+
+			select {
+			case <-time.After(deployRequestWaitBackoff(attempt)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// deployOperationLogLines renders a deploy request's in-flight operations as
+// human-readable progress log lines.
+func deployOperationLogLines(ops []*DeployOperation) []LogLine {
+	lines := make([]LogLine, 0, len(ops))
+	for _, op := range ops {
+		lines = append(lines, LogLine{
+			Time:    time.Now(),
+			Level:   "info",
+			Message: fmt.Sprintf("%s: %s (%d/%d rows copied)", op.Table, op.State, op.RowsCopied, op.RowsTotal),
+		})
 	}
 
-	return drr, nil
+	return lines
 }
 
 func deployRequestsAPIPath(org, db string) string {
@@ -0,0 +1,274 @@
+package planetscale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func withFastWaitBackoff(t *testing.T) {
+	t.Helper()
+
+	origInitial := deployRequestWaitInitialBackoff
+	origMax := deployRequestWaitMaxBackoff
+
+	deployRequestWaitInitialBackoff = time.Millisecond
+	deployRequestWaitMaxBackoff = 5 * time.Millisecond
+
+	t.Cleanup(func() {
+		deployRequestWaitInitialBackoff = origInitial
+		deployRequestWaitMaxBackoff = origMax
+	})
+}
+
+func TestDeployRequests_Wait(t *testing.T) {
+	c := qt.New(t)
+	withFastWaitBackoff(t)
+
+	states := []string{"pending", "in_progress", "complete"}
+	var calls int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&calls, 1) - 1
+		state := states[len(states)-1]
+		if int(i) < len(states) {
+			state = states[i]
+		}
+
+		w.WriteHeader(200)
+		out := fmt.Sprintf(`{"id": "deploy-request-1", "number": 1, "deployment_state": "%s"}`, state)
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	dr, err := client.DeployRequests.Wait(context.Background(), &WaitDeployRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Number:       1,
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(dr.DeploymentState, qt.Equals, "complete")
+	c.Assert(int(atomic.LoadInt32(&calls)), qt.Equals, len(states))
+}
+
+func TestDeployRequests_Watch(t *testing.T) {
+	c := qt.New(t)
+	withFastWaitBackoff(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		out := `{
+    "id": "deploy-request-1",
+    "number": 1,
+    "deployment_state": "complete",
+    "operations": [
+        {"state": "complete", "table": "users", "rows_copied": 100, "rows_total": 100}
+    ]
+}`
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	var events []DeployEvent
+	for ev := range client.DeployRequests.Watch(context.Background(), &WaitDeployRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Number:       1,
+	}) {
+		events = append(events, ev)
+	}
+
+	c.Assert(events, qt.HasLen, 1)
+	c.Assert(events[0].Err, qt.IsNil)
+	c.Assert(events[0].LogLines, qt.HasLen, 1)
+	c.Assert(events[0].LogLines[0].Message, qt.Equals, "users: complete (100/100 rows copied)")
+}
+
+func TestDeployRequests_Wait_contextCancelled(t *testing.T) {
+	c := qt.New(t)
+	withFastWaitBackoff(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`{"id": "deploy-request-1", "number": 1, "deployment_state": "pending"}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.DeployRequests.Wait(ctx, &WaitDeployRequest{
+			Organization: "my-org",
+			Database:     "my-db",
+			Number:       1,
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after context cancellation")
+	}
+}
+
+func TestIsTerminalDeploymentState(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{"complete", true},
+		{"failed", true},
+		{"cancelled", true},
+		{"pending", false},
+		{"in_progress", false},
+		{"", false},
+	}
+
+	for _, tc := range tests {
+		c.Assert(isTerminalDeploymentState(tc.state), qt.Equals, tc.want, qt.Commentf("state %q", tc.state))
+	}
+}
+
+func TestDeployRequests_Diff(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.Method, qt.Equals, http.MethodGet)
+		w.WriteHeader(200)
+		out := `{
+    "operations": [
+        {"table": "users", "kind": "alter", "raw_sql": "ALTER TABLE users ADD COLUMN age INT NOT NULL", "estimated_rows": 100}
+    ],
+    "warnings": [
+        {"table": "users", "message": "this change requires a full table rebuild"}
+    ],
+    "raw": "ALTER TABLE users ADD COLUMN age INT NOT NULL;"
+}`
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	diff, _, err := client.DeployRequests.Diff(context.Background(), &DiffDeployRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Number:       1,
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(diff.Operations, qt.HasLen, 1)
+	c.Assert(diff.Warnings, qt.HasLen, 1)
+	c.Assert(diff.ContainsDestructive(), qt.IsTrue)
+}
+
+func TestDeployDiff_ContainsDestructive(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name string
+		diff *DeployDiff
+		want bool
+	}{
+		{
+			name: "drop",
+			diff: &DeployDiff{Operations: []*SchemaOperation{{Kind: "drop", Table: "users"}}},
+			want: true,
+		},
+		{
+			name: "non-null addition without default",
+			diff: &DeployDiff{Operations: []*SchemaOperation{{Kind: "alter", RawSQL: "ALTER TABLE users ADD COLUMN age INT NOT NULL"}}},
+			want: true,
+		},
+		{
+			name: "non-null addition with default",
+			diff: &DeployDiff{Operations: []*SchemaOperation{{Kind: "alter", RawSQL: "ALTER TABLE users ADD COLUMN age INT NOT NULL DEFAULT 0"}}},
+			want: false,
+		},
+		{
+			name: "primary key change",
+			diff: &DeployDiff{Operations: []*SchemaOperation{{Kind: "alter", RawSQL: "ALTER TABLE users DROP PRIMARY KEY, ADD PRIMARY KEY (id, tenant_id)"}}},
+			want: true,
+		},
+		{
+			name: "benign create",
+			diff: &DeployDiff{Operations: []*SchemaOperation{{Kind: "create", Table: "widgets"}}},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		c.Assert(tc.diff.ContainsDestructive(), qt.Equals, tc.want, qt.Commentf(tc.name))
+	}
+}
+
+func TestDeployRequests_CreateReview_usesPost(t *testing.T) {
+	c := qt.New(t)
+
+	var gotMethod string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.WriteHeader(200)
+		_, err := w.Write([]byte(`{"id": "review-1", "state": "approved"}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, _, err = client.DeployRequests.CreateReview(context.Background(), &ReviewDeployRequestRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Number:       1,
+		State:        "approved",
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotMethod, qt.Equals, http.MethodPost)
+}
+
+func TestDeployRequestWaitBackoff(t *testing.T) {
+	c := qt.New(t)
+
+	origInitial := deployRequestWaitInitialBackoff
+	origMax := deployRequestWaitMaxBackoff
+	deployRequestWaitInitialBackoff = time.Second
+	deployRequestWaitMaxBackoff = 30 * time.Second
+	defer func() {
+		deployRequestWaitInitialBackoff = origInitial
+		deployRequestWaitMaxBackoff = origMax
+	}()
+
+	for attempt := 0; attempt < 10; attempt++ {
+		backoff := deployRequestWaitBackoff(attempt)
+		c.Assert(backoff >= 0, qt.IsTrue)
+		c.Assert(backoff <= deployRequestWaitMaxBackoff, qt.IsTrue)
+	}
+}
@@ -0,0 +1,64 @@
+package planetscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ErrorResponse is returned when the PlanetScale API responds with a
+// non-2xx status code.
+type ErrorResponse struct {
+	Response *http.Response `json:"-"`
+
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("%v %v: %d %s",
+		e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, e.Message)
+}
+
+// NotFoundError is returned when the API responds with a 404, meaning the
+// requested resource does not exist.
+type NotFoundError struct {
+	msg string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.msg
+}
+
+// RateLimitError is returned when the API responds with a 429, meaning the
+// caller has exceeded their rate limit. Callers can inspect Rate to decide
+// how long to back off before retrying.
+type RateLimitError struct {
+	Rate     Rate
+	Response *http.Response
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v %v: %d rate limit exceeded, remaining %d, resets at %s",
+		e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, e.Rate.Remaining, e.Rate.Reset)
+}
+
+// CheckResponse checks a PlanetScale API response for errors, returning a
+// *NotFoundError for a 404 and a generic *ErrorResponse for any other non-2xx
+// status. A 429 is turned into a *RateLimitError by Client.Do, not here.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+
+	errorResponse := &ErrorResponse{Response: r}
+	if err := json.NewDecoder(r.Body).Decode(errorResponse); err != nil {
+		errorResponse.Message = err.Error()
+	}
+
+	if r.StatusCode == http.StatusNotFound {
+		return &NotFoundError{msg: errorResponse.Error()}
+	}
+
+	return errorResponse
+}
@@ -0,0 +1,304 @@
+package planetscale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DatabaseBranchPassword represents a PlanetScale password for a particular
+// database branch.
+type DatabaseBranchPassword struct {
+	PublicID string `json:"id"`
+	Name     string `json:"display_name"`
+	UserName string `json:"username"`
+
+	PlainText string `json:"plain_text"`
+	Role      string `json:"role"`
+	Renewable bool   `json:"renewable"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// DatabaseBranchPasswordRequest encapsulates the request for creating a new
+// password for a database branch.
+type DatabaseBranchPasswordRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Branch       string `json:"-"`
+
+	Name string `json:"display_name,omitempty"`
+	Role string `json:"role,omitempty"`
+}
+
+// GetDatabaseBranchPasswordRequest encapsulates the request for getting a
+// single database branch password.
+type GetDatabaseBranchPasswordRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Branch       string `json:"-"`
+	PasswordId   string `json:"-"`
+}
+
+// ListDatabaseBranchPasswordRequest encapsulates the request for listing the
+// passwords of a database branch.
+type ListDatabaseBranchPasswordRequest struct {
+	Organization string
+	Database     string
+	Branch       string
+
+	ListOptions
+}
+
+// RotatePasswordRequest encapsulates the request for rotating the secret of
+// an existing database branch password.
+type RotatePasswordRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Branch       string `json:"-"`
+	PasswordId   string `json:"-"`
+
+	// GracePeriod is how long the password being rotated away from remains
+	// valid for. A zero value revokes it immediately.
+	GracePeriod time.Duration `json:"-"`
+}
+
+// RotationResult is returned by Rotate, pairing the newly minted password
+// with the credential it replaced.
+type RotationResult struct {
+	New      *DatabaseBranchPassword `json:"new"`
+	Previous *DatabaseBranchPassword `json:"previous"`
+}
+
+// RevokePasswordRequest encapsulates the request for revoking a database
+// branch password ahead of its expiry.
+type RevokePasswordRequest struct {
+	Organization string `json:"-"`
+	Database     string `json:"-"`
+	Branch       string `json:"-"`
+	PasswordId   string `json:"-"`
+}
+
+// RevokedPassword represents a previously issued database branch password
+// that has since been revoked.
+type RevokedPassword struct {
+	PublicID string `json:"id"`
+	Name     string `json:"display_name"`
+	UserName string `json:"username"`
+	Role     string `json:"role"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt time.Time  `json:"revoked_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ListRevokedPasswordsRequest encapsulates the request for listing the
+// revoked passwords of a database branch, for credential auditing.
+type ListRevokedPasswordsRequest struct {
+	Organization string
+	Database     string
+	Branch       string
+
+	ListOptions
+}
+
+// PasswordsService is an interface for communicating with the PlanetScale
+// database branch passwords API.
+type PasswordsService interface {
+	Create(context.Context, *DatabaseBranchPasswordRequest) (*DatabaseBranchPassword, *Response, error)
+	Get(context.Context, *GetDatabaseBranchPasswordRequest) (*DatabaseBranchPassword, *Response, error)
+	List(context.Context, *ListDatabaseBranchPasswordRequest) ([]*DatabaseBranchPassword, *Response, error)
+	Rotate(context.Context, *RotatePasswordRequest) (*RotationResult, *Response, error)
+	Revoke(context.Context, *RevokePasswordRequest) (*Response, error)
+	ListRevoked(context.Context, *ListRevokedPasswordsRequest) ([]*RevokedPassword, *Response, error)
+}
+
+type passwordsService struct {
+	client *Client
+}
+
+var _ PasswordsService = &passwordsService{}
+
+func NewPasswordsService(client *Client) *passwordsService {
+	return &passwordsService{
+		client: client,
+	}
+}
+
+type passwordsResponse struct {
+	Passwords []*DatabaseBranchPassword `json:"data"`
+}
+
+// Create creates a new password for a database branch.
+func (p *passwordsService) Create(ctx context.Context, createReq *DatabaseBranchPasswordRequest) (*DatabaseBranchPassword, *Response, error) {
+	req, err := p.client.newRequest(http.MethodPost, passwordsAPIPath(createReq.Organization, createReq.Database, createReq.Branch), createReq)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	password := &DatabaseBranchPassword{}
+	if err := json.NewDecoder(res.Body).Decode(password); err != nil {
+		return nil, res, err
+	}
+
+	return password, res, nil
+}
+
+// Get fetches a single database branch password.
+func (p *passwordsService) Get(ctx context.Context, getReq *GetDatabaseBranchPasswordRequest) (*DatabaseBranchPassword, *Response, error) {
+	req, err := p.client.newRequest(http.MethodGet, passwordAPIPath(getReq.Organization, getReq.Database, getReq.Branch, getReq.PasswordId), nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	password := &DatabaseBranchPassword{}
+	if err := json.NewDecoder(res.Body).Decode(password); err != nil {
+		return nil, res, err
+	}
+
+	return password, res, nil
+}
+
+// List returns all of the passwords for a database branch.
+func (p *passwordsService) List(ctx context.Context, listReq *ListDatabaseBranchPasswordRequest) ([]*DatabaseBranchPassword, *Response, error) {
+	path, err := addListOptions(passwordsAPIPath(listReq.Organization, listReq.Database, listReq.Branch), listReq.ListOptions)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error adding list options")
+	}
+
+	req, err := p.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	passwords := &passwordsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(passwords); err != nil {
+		return nil, res, err
+	}
+
+	return passwords.Passwords, res, nil
+}
+
+// rotatePasswordRequestBody is the wire representation of a rotate request;
+// GracePeriod is sent to the API in seconds.
+type rotatePasswordRequestBody struct {
+	GracePeriodSeconds int64 `json:"grace_period_seconds,omitempty"`
+}
+
+// Rotate issues a new secret for an existing database branch password,
+// keeping the old credential valid for GracePeriod so in-flight connections
+// aren't dropped.
+func (p *passwordsService) Rotate(ctx context.Context, rotateReq *RotatePasswordRequest) (*RotationResult, *Response, error) {
+	body := &rotatePasswordRequestBody{}
+	if rotateReq.GracePeriod > 0 {
+		body.GracePeriodSeconds = int64(rotateReq.GracePeriod.Seconds())
+	}
+
+	path := passwordRotateAPIPath(rotateReq.Organization, rotateReq.Database, rotateReq.Branch, rotateReq.PasswordId)
+	req, err := p.client.newRequest(http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	result := &RotationResult{}
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return nil, res, err
+	}
+
+	return result, res, nil
+}
+
+// Revoke revokes a database branch password ahead of its expiry. Revoking an
+// already-revoked password returns a *NotFoundError.
+func (p *passwordsService) Revoke(ctx context.Context, revokeReq *RevokePasswordRequest) (*Response, error) {
+	path := passwordAPIPath(revokeReq.Organization, revokeReq.Database, revokeReq.Branch, revokeReq.PasswordId)
+	req, err := p.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return res, err
+	}
+	defer res.Body.Close()
+
+	return res, nil
+}
+
+type revokedPasswordsResponse struct {
+	RevokedPasswords []*RevokedPassword `json:"data"`
+}
+
+// ListRevoked returns the revoked passwords for a database branch, for
+// credential auditing.
+func (p *passwordsService) ListRevoked(ctx context.Context, listReq *ListRevokedPasswordsRequest) ([]*RevokedPassword, *Response, error) {
+	path, err := addListOptions(revokedPasswordsAPIPath(listReq.Organization, listReq.Database, listReq.Branch), listReq.ListOptions)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error adding list options")
+	}
+
+	req, err := p.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating http request")
+	}
+
+	res, err := p.client.Do(ctx, req)
+	if err != nil {
+		return nil, res, err
+	}
+	defer res.Body.Close()
+
+	revoked := &revokedPasswordsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(revoked); err != nil {
+		return nil, res, err
+	}
+
+	return revoked.RevokedPasswords, res, nil
+}
+
+func passwordsAPIPath(org, db, branch string) string {
+	return fmt.Sprintf("%s/%s/branches/%s/passwords", databasesAPIPath(org), db, branch)
+}
+
+func passwordAPIPath(org, db, branch, id string) string {
+	return fmt.Sprintf("%s/%s", passwordsAPIPath(org, db, branch), id)
+}
+
+func passwordRotateAPIPath(org, db, branch, id string) string {
+	return fmt.Sprintf("%s/rotate", passwordAPIPath(org, db, branch, id))
+}
+
+func revokedPasswordsAPIPath(org, db, branch string) string {
+	return fmt.Sprintf("%s/revoked", passwordsAPIPath(org, db, branch))
+}
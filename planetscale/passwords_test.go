@@ -2,6 +2,8 @@ package planetscale
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -38,7 +40,7 @@ func TestPasswords_Create(t *testing.T) {
 	db := "my-db"
 	branch := "my-branch"
 
-	password, err := client.Passwords.Create(ctx, &DatabaseBranchPasswordRequest{
+	password, _, err := client.Passwords.Create(ctx, &DatabaseBranchPasswordRequest{
 		Organization: org,
 		Database:     db,
 		Branch:       branch,
@@ -85,7 +87,7 @@ func TestPasswords_List(t *testing.T) {
 	db := "planetscale-go-test-db"
 	branch := "my-branch"
 
-	passwords, err := client.Passwords.List(ctx, &ListDatabaseBranchPasswordRequest{
+	passwords, _, err := client.Passwords.List(ctx, &ListDatabaseBranchPasswordRequest{
 		Organization: org,
 		Database:     db,
 		Branch:       branch,
@@ -119,7 +121,7 @@ func TestPasswords_ListEmpty(t *testing.T) {
 	db := "planetscale-go-test-db"
 	branch := "my-branch"
 
-	passwords, err := client.Passwords.List(ctx, &ListDatabaseBranchPasswordRequest{
+	passwords, _, err := client.Passwords.List(ctx, &ListDatabaseBranchPasswordRequest{
 		Organization: org,
 		Database:     db,
 		Branch:       branch,
@@ -153,7 +155,7 @@ func TestPasswords_Get(t *testing.T) {
 	db := "planetscale-go-test-db"
 	branch := "my-branch"
 
-	password, err := client.Passwords.Get(ctx, &GetDatabaseBranchPasswordRequest{
+	password, _, err := client.Passwords.Get(ctx, &GetDatabaseBranchPasswordRequest{
 		Organization: org,
 		Database:     db,
 		Branch:       branch,
@@ -170,4 +172,128 @@ func TestPasswords_Get(t *testing.T) {
 
 	c.Assert(err, qt.IsNil)
 	c.Assert(password, qt.DeepEquals, want)
-}
\ No newline at end of file
+}
+
+func TestPasswords_Rotate_withGracePeriod(t *testing.T) {
+	c := qt.New(t)
+
+	var gotBody rotatePasswordRequestBody
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.Method, qt.Equals, http.MethodPost)
+		c.Assert(json.NewDecoder(r.Body).Decode(&gotBody), qt.IsNil)
+
+		w.WriteHeader(200)
+		out := `{
+    "new": {"id": "new-password", "username": "new-password", "plain_text": "new-plain-text"},
+    "previous": {"id": "planetscale-go-test-password", "revoked_at": "2021-01-14T11:19:23.000Z", "expires_at": "2021-01-14T11:29:23.000Z"}
+}`
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	result, _, err := client.Passwords.Rotate(context.Background(), &RotatePasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+		PasswordId:   testPasswordID,
+		GracePeriod:  10 * time.Minute,
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotBody.GracePeriodSeconds, qt.Equals, int64(600))
+	c.Assert(result.New.PlainText, qt.Equals, "new-plain-text")
+	c.Assert(result.Previous.RevokedAt, qt.IsNotNil)
+	c.Assert(result.Previous.ExpiresAt, qt.IsNotNil)
+}
+
+func TestPasswords_Rotate_noGracePeriod(t *testing.T) {
+	c := qt.New(t)
+
+	var gotBody rotatePasswordRequestBody
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(json.NewDecoder(r.Body).Decode(&gotBody), qt.IsNil)
+
+		w.WriteHeader(200)
+		out := `{"new": {"id": "new-password"}, "previous": {"id": "planetscale-go-test-password"}}`
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, _, err = client.Passwords.Rotate(context.Background(), &RotatePasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+		PasswordId:   testPasswordID,
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotBody.GracePeriodSeconds, qt.Equals, int64(0))
+}
+
+func TestPasswords_Revoke_alreadyRevoked(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.Method, qt.Equals, http.MethodDelete)
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"code": "not_found", "message": "password not found"}`))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	_, err = client.Passwords.Revoke(context.Background(), &RevokePasswordRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+		PasswordId:   testPasswordID,
+	})
+
+	c.Assert(err, qt.Not(qt.IsNil))
+
+	var notFound *NotFoundError
+	c.Assert(errors.As(err, &notFound), qt.IsTrue)
+}
+
+func TestPasswords_ListRevoked(t *testing.T) {
+	c := qt.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Query().Get("page"), qt.Equals, "2")
+		c.Assert(r.URL.Query().Get("per_page"), qt.Equals, "5")
+
+		w.WriteHeader(200)
+		out := `{
+    "data": [
+        {"id": "planetscale-go-test-password", "revoked_at": "2021-01-14T11:19:23.000Z"}
+    ]
+}`
+		_, err := w.Write([]byte(out))
+		c.Assert(err, qt.IsNil)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(WithBaseURL(ts.URL))
+	c.Assert(err, qt.IsNil)
+
+	revoked, _, err := client.Passwords.ListRevoked(context.Background(), &ListRevokedPasswordsRequest{
+		Organization: "my-org",
+		Database:     "my-db",
+		Branch:       "my-branch",
+		ListOptions:  ListOptions{Page: 2, PerPage: 5},
+	})
+
+	c.Assert(err, qt.IsNil)
+	c.Assert(revoked, qt.HasLen, 1)
+	c.Assert(revoked[0].PublicID, qt.Equals, testPasswordID)
+}